@@ -1,9 +1,14 @@
 package gosignal
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Function struct {
-	Func func(context.Context)
+	// Func returns an error on failure. Errors from a hook's functions are
+	// aggregated (via errors.Join) into the error returned by hook.Exec.
+	Func func(context.Context) error
 
 	Name      string
 	Desc      string
@@ -11,6 +16,16 @@ type Function struct {
 
 	Order      uint16
 	Concurrent bool
+
+	// Timeout, if non-zero, bounds Func with a context.WithTimeout derived
+	// from the ctx passed to Exec.
+	Timeout time.Duration
+
+	// DependsOn lists the names of functions, registered on the same hook,
+	// that must run before this one. Consulted by TopologicalOrder, and
+	// under any strategy by Exec within a run of consecutive Concurrent
+	// functions; a cycle is rejected at registration time by hook.Function.
+	DependsOn []string
 }
 
 type Notify struct {
@@ -23,4 +38,9 @@ type Notify struct {
 
 	Order       uint16
 	NonBlocking bool
+
+	// DependsOn lists the names of notifies, registered on the same hook,
+	// that must run before this one. Only consulted by TopologicalOrder;
+	// a cycle is rejected at registration time by hook.Notify.
+	DependsOn []string
 }
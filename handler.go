@@ -9,7 +9,9 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 )
 
 var (
@@ -32,6 +34,25 @@ type OSSignal struct {
 	Exit     bool
 	ExitType et
 	ExitCode int
+
+	// ReceivedAt is when the handler observed this signal or exit call.
+	ReceivedAt time.Time
+	// Sequence is a monotonically increasing counter assigned per OSSignal,
+	// so hook authors can distinguish repeated signals (e.g. to debounce).
+	Sequence uint64
+}
+
+// signalContextKey is the unexported type used to stash the current
+// OSSignal on a context.Context, so it can't collide with keys used by
+// other packages.
+type signalContextKey struct{}
+
+// SignalFromContext returns the OSSignal the handler attached to ctx, if
+// any. It is set on every context a handler passes to a signal or exit
+// hook's Exec.
+func SignalFromContext(ctx context.Context) (*OSSignal, bool) {
+	s, ok := ctx.Value(signalContextKey{}).(*OSSignal)
+	return s, ok
 }
 
 type Handler interface {
@@ -43,6 +64,18 @@ type Handler interface {
 	Get(os.Signal) Hook
 	Gets(os.Signal) Hook
 
+	// Use registers middleware that will be composed around every hook.Exec
+	// invocation made by this handler, outermost first.
+	Use(middleware ...HookMiddleware)
+
+	// RegisterDrainable adds d to the set of in-flight trackers that Exit
+	// waits on (up to ShutdownGracePeriod) before the process exits.
+	RegisterDrainable(d Drainable)
+	// SetShutdownGracePeriod bounds how long Exit waits for registered
+	// Drainables to finish. The zero value (the default) puts no bound on
+	// the wait: Exit waits until every Drainable's Wait returns.
+	SetShutdownGracePeriod(d time.Duration)
+
 	Loop()
 	Exit(int)
 }
@@ -53,6 +86,13 @@ type handler struct {
 	hookMap   map[os.Signal]Hook
 	capturing []os.Signal
 
+	middlewares []HookMiddleware
+
+	shutdownGrace time.Duration
+	drainables    []Drainable
+
+	seq atomic.Uint64
+
 	sigCh  chan os.Signal
 	exitCh chan struct{}
 	mu     sync.Mutex
@@ -148,19 +188,21 @@ func (h *handler) Gets(sig os.Signal) Hook {
 
 func (h *handler) handleSignal(sig os.Signal) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
 	if h.exitLock {
+		h.mu.Unlock()
 		return
 	}
 
 	s := &OSSignal{
-		Signal:   sig,
-		Exit:     false,
-		ExitType: ExitTypeSignal,
-		ExitCode: 0,
+		Signal:     sig,
+		Exit:       false,
+		ExitType:   ExitTypeSignal,
+		ExitCode:   0,
+		ReceivedAt: time.Now(),
+		Sequence:   h.seq.Add(1),
 	}
 	ctx := context.TODO()
-	ctx = context.WithValue(ctx, "signal", s)
+	ctx = context.WithValue(ctx, signalContextKey{}, s)
 
 	switch sig {
 	case syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
@@ -168,15 +210,32 @@ func (h *handler) handleSignal(sig os.Signal) {
 		s.ExitCode = 0
 	}
 
-	if hook, ok := h.hookMap[sig]; ok {
-		hook.Exec(ctx)
+	hook, hookOK := h.hookMap[sig]
+	mws := h.middlewares
+	h.mu.Unlock()
+
+	execHook := func(ctx context.Context, s *OSSignal) error {
+		if hookOK {
+			return hook.Exec(ctx)
+		}
+		return nil
 	}
+	chain(mws, execHook)(ctx, s)
+
 	switch sig {
 	case syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
 		h.exit(0, ExitTypeSignal, sig)
 	}
 }
 
+// Use registers middleware that will be composed around every hook.Exec
+// invocation made by this handler, outermost first.
+func (h *handler) Use(middleware ...HookMiddleware) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.middlewares = append(h.middlewares, middleware...)
+}
+
 func (h *handler) Loop() {
 	h.mu.Lock()
 	if h.exitLock {
@@ -202,36 +261,194 @@ handling_loop:
 }
 
 func (h *handler) exit(code int, t et, sig os.Signal) {
+	h.mu.Lock()
 	if h.exitLock {
+		h.mu.Unlock()
 		return
 	}
 	h.exitLock = true
+
+	exitHook := h.exitHook
+	mws := h.middlewares
+	capturing := append([]os.Signal(nil), h.capturing...)
+	grace := h.shutdownGrace
+	drainables := append([]Drainable(nil), h.drainables...)
+	h.mu.Unlock()
+
+	s := &OSSignal{
+		Signal:     sig,
+		Exit:       true,
+		ExitType:   t,
+		ExitCode:   code,
+		ReceivedAt: time.Now(),
+		Sequence:   h.seq.Add(1),
+	}
+	ctx := context.TODO()
+	ctx = context.WithValue(ctx, signalContextKey{}, s)
+
+	execExit := func(ctx context.Context, s *OSSignal) error {
+		if exitHook == nil {
+			return nil
+		}
+		return exitHook.Exec(ctx)
+	}
+
+	// The exit hook runs unbounded and synchronously by default (no timeout,
+	// no Concurrent fan-out), so a hung Function here must not wedge the
+	// second-signal escape hatch: race it against h.sigCh the same way
+	// drain races the drainables below.
+	execDone := make(chan struct{})
+	var execErr error
+	go func() {
+		execErr = chain(mws, execExit)(ctx, s)
+		close(execDone)
+	}()
+	h.awaitOrForceExit(execDone, nil)
+
+	if errors.Is(execErr, ErrExitVetoed) {
+		h.mu.Lock()
+		h.exitLock = false
+		h.mu.Unlock()
+		return
+	}
+
+	h.mu.Lock()
 	select {
 	case h.exitCh <- struct{}{}:
 	default:
 	}
 	h.loopLock = false
+	h.mu.Unlock()
+
+	h.drain(ctx, grace, drainables)
+
+	signal.Reset(capturing...)
+	os.Exit(code)
+}
+
+// drain waits up to grace (without a bound when grace is 0) for drainables
+// to finish before returning control to exit so it can call os.Exit. While
+// draining it keeps reading h.sigCh itself (Loop is blocked inside exit and
+// isn't reading it): a second SIGINT/SIGTERM/SIGQUIT cancels the drain and
+// exits immediately (130 for SIGINT, 143 for SIGTERM, 1 for SIGQUIT; see
+// forceExitCode), instead of waiting out the rest of the grace period.
+func (h *handler) drain(parent context.Context, grace time.Duration, drainables []Drainable) {
+	if len(drainables) == 0 {
+		return
+	}
+
+	ctx := parent
+	if grace > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, grace)
+		defer cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, d := range drainables {
+			d := d
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = d.Wait(ctx)
+			}()
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	h.awaitOrForceExit(done, ctx.Done())
+}
+
+// awaitOrForceExit blocks until done is closed or cancel is readable,
+// whichever comes first. A nil cancel is never ready, so it's safe to pass
+// when there's nothing to cancel on. While waiting it reads h.sigCh itself
+// (Loop is blocked inside exit and isn't reading it): a second
+// SIGINT/SIGTERM/SIGQUIT exits immediately with the code forceExitCode gives
+// it (130 for SIGINT, 143 for SIGTERM, 1 for SIGQUIT) instead of waiting for
+// done/cancel. Any other signal (e.g. SIGCHLD
+// feeding a Reaper a Drainable is waiting on) is dispatched to its own
+// registered hook instead of being dropped, the same way Loop would have
+// handled it.
+func (h *handler) awaitOrForceExit(done <-chan struct{}, cancel <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-cancel:
+			return
+		case sig := <-h.sigCh:
+			switch sig {
+			case syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
+				os.Exit(forceExitCode(sig))
+			default:
+				h.dispatchDuringShutdown(sig)
+			}
+		}
+	}
+}
+
+// dispatchDuringShutdown runs sig's registered hook in place, composed with
+// the handler's middlewares the same way handleSignal does. It's used by
+// awaitOrForceExit, which stands in for Loop's select while Loop itself is
+// blocked inside exit; without it, a non-terminal signal arriving during the
+// exit-hook race or the drain wait would never reach its hook.
+func (h *handler) dispatchDuringShutdown(sig os.Signal) {
+	h.mu.Lock()
+	hook, hookOK := h.hookMap[sig]
+	mws := h.middlewares
+	h.mu.Unlock()
+
+	if !hookOK {
+		return
+	}
 
 	s := &OSSignal{
-		Signal:   sig,
-		Exit:     true,
-		ExitType: t,
-		ExitCode: code,
+		Signal:     sig,
+		ExitType:   ExitTypeSignal,
+		ReceivedAt: time.Now(),
+		Sequence:   h.seq.Add(1),
 	}
-	ctx := context.TODO()
-	ctx = context.WithValue(ctx, "signal", s)
+	ctx := context.WithValue(context.TODO(), signalContextKey{}, s)
 
-	if h.exitHook != nil {
-		h.exitHook.Exec(ctx)
+	execHook := func(ctx context.Context, s *OSSignal) error {
+		return hook.Exec(ctx)
+	}
+	_ = chain(mws, execHook)(ctx, s)
+}
+
+func forceExitCode(sig os.Signal) int {
+	switch sig {
+	case syscall.SIGINT:
+		return 130
+	case syscall.SIGTERM:
+		return 143
+	default:
+		return 1
 	}
-	signal.Reset(h.capturing...)
-	os.Exit(code)
 }
 
 func (h *handler) Exit(code int) {
+	h.exit(code, ExitTypeManual, nil)
+}
+
+// RegisterDrainable adds d to the set of in-flight trackers that Exit waits
+// on (up to ShutdownGracePeriod) before the process exits.
+func (h *handler) RegisterDrainable(d Drainable) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.exit(code, ExitTypeManual, nil)
+	h.drainables = append(h.drainables, d)
+}
+
+// SetShutdownGracePeriod bounds how long Exit waits for registered
+// Drainables to finish. The zero value (the default) puts no bound on the
+// wait: Exit waits until every Drainable's Wait returns.
+func (h *handler) SetShutdownGracePeriod(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shutdownGrace = d
 }
 
 var _ Handler = (*handler)(nil)
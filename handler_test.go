@@ -0,0 +1,138 @@
+package gosignal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExitVetoedByMiddlewareAborts(t *testing.T) {
+	h := newHandler().(*handler)
+
+	ran := false
+	_ = h.SetExit(NewHook("exit", "test"))
+	_ = h.GetExit().Function(&Function{Name: "f", Func: func(ctx context.Context) error {
+		ran = true
+		return nil
+	}})
+	h.Use(func(next FuncHandle) FuncHandle {
+		return func(ctx context.Context, sig *OSSignal) error {
+			if err := next(ctx, sig); err != nil {
+				return err
+			}
+			return ErrExitVetoed
+		}
+	})
+
+	h.Exit(0)
+
+	if !ran {
+		t.Fatal("expected the exit hook to run before the veto was observed")
+	}
+	if h.exitLock {
+		t.Fatal("expected exitLock to be released after a veto")
+	}
+}
+
+type waitDrainable struct {
+	wait func(ctx context.Context) error
+}
+
+func (d waitDrainable) Wait(ctx context.Context) error { return d.wait(ctx) }
+
+func TestDrainWaitsForDrainables(t *testing.T) {
+	h := newHandler().(*handler)
+
+	done := make(chan struct{})
+	d := waitDrainable{wait: func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		close(done)
+		return nil
+	}}
+
+	start := time.Now()
+	h.drain(context.Background(), 0, []Drainable{d})
+	elapsed := time.Since(start)
+
+	select {
+	case <-done:
+	default:
+		t.Fatal("expected drain to wait for the Drainable to finish")
+	}
+	if elapsed < 20*time.Millisecond {
+		t.Fatalf("expected drain to block for at least 20ms, took %s", elapsed)
+	}
+}
+
+func TestDrainTimesOutAfterGracePeriod(t *testing.T) {
+	h := newHandler().(*handler)
+
+	d := waitDrainable{wait: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	start := time.Now()
+	h.drain(context.Background(), 10*time.Millisecond, []Drainable{d})
+	elapsed := time.Since(start)
+
+	if elapsed >= 500*time.Millisecond {
+		t.Fatalf("expected drain to be bounded by the grace period, took %s", elapsed)
+	}
+}
+
+func TestAwaitOrForceExitDispatchesNonTerminalSignal(t *testing.T) {
+	h := newHandler().(*handler)
+
+	ran := make(chan struct{}, 1)
+	hook := NewHook("fake", "test")
+	_ = hook.Function(&Function{Name: "f", Func: func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}})
+	var sig os.Signal = fakeSignal("fake")
+	_ = h.Set(sig, hook)
+
+	done := make(chan struct{})
+	go func() {
+		h.sigCh <- sig
+		close(done)
+	}()
+
+	h.awaitOrForceExit(done, nil)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the non-terminal signal's hook to run instead of being dropped")
+	}
+}
+
+// TestForceExitOnSecondSignal exercises the os.Exit path in awaitOrForceExit.
+// Since that path terminates the process, it's run in a helper subprocess
+// (the standard pattern for testing code that calls os.Exit) and the parent
+// only inspects the subprocess's exit code.
+func TestForceExitOnSecondSignal(t *testing.T) {
+	if os.Getenv("GOSIGNAL_FORCE_EXIT_HELPER") == "1" {
+		h := newHandler().(*handler)
+		go func() { h.sigCh <- syscall.SIGINT }()
+		h.awaitOrForceExit(make(chan struct{}), nil)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestForceExitOnSecondSignal")
+	cmd.Env = append(os.Environ(), "GOSIGNAL_FORCE_EXIT_HELPER=1")
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected the helper process to exit with an error, got %v", err)
+	}
+	if got := exitErr.ExitCode(); got != 130 {
+		t.Fatalf("expected exit code 130, got %d", got)
+	}
+}
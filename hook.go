@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"sync"
 )
 
@@ -35,6 +34,15 @@ type hook struct {
 	functions map[string]*Function
 	notifies  map[string]*Notify
 
+	// functionList and notifyList hold registrations in insertion order;
+	// they are the source of truth that orderStrategy sorts into
+	// functionOrder/notifyOrder before each Exec.
+	functionList []*Function
+	notifyList   []*Notify
+
+	orderStrategy OrderStrategy
+	maxParallel   int
+
 	doReorder     bool
 	functionOrder []*Function
 	notifyOrder   []*Notify
@@ -42,55 +50,36 @@ type hook struct {
 	mu sync.RWMutex
 }
 
-func (h *hook) reorder() {
-	if !h.doReorder {
-		return
-	}
+// HookOption configures a hook at construction time.
+type HookOption func(*hook)
 
-	clear(h.functionOrder)
-	clear(h.notifyOrder)
-	var lowest uint16 = math.MaxUint16
-	var lowestName string
-
-	// order functons
-	for len(h.functions) > 0 {
-		lowest = math.MaxUint16
-		lowestName = ""
-
-		for _, function := range h.functions {
-			if function.Order <= lowest {
-				lowest = function.Order
-				lowestName = function.Name
-			}
+// WithOrderStrategy sets the strategy used to sort functions and notifies
+// before each Exec. The default is AscendingOrder.
+func WithOrderStrategy(strategy OrderStrategy) HookOption {
+	return func(h *hook) {
+		if strategy != nil {
+			h.orderStrategy = strategy
 		}
-
-		h.functionOrder = append(h.functionOrder, h.functions[lowestName])
-		delete(h.functions, lowestName)
 	}
+}
 
-	// order notifies
-	for len(h.notifies) > 0 {
-		lowest = math.MaxUint16
-		lowestName = ""
-
-		for _, notify := range h.notifies {
-			if notify.Order < lowest {
-				lowest = notify.Order
-				lowestName = notify.Name
-			}
-		}
-
-		h.notifyOrder = append(h.notifyOrder, h.notifies[lowestName])
-		delete(h.notifies, lowestName)
+// WithMaxParallel bounds how many consecutive Concurrent functions run at
+// once. n <= 0 means unbounded (the default).
+func WithMaxParallel(n int) HookOption {
+	return func(h *hook) {
+		h.maxParallel = n
 	}
+}
 
-	// restore h.functions and h.notifies
-	for _, function := range h.functionOrder {
-		h.functions[function.Name] = function
-	}
-	for _, notify := range h.notifyOrder {
-		h.notifies[notify.Name] = notify
+func (h *hook) reorder() {
+	if !h.doReorder {
+		return
 	}
+
+	h.functionOrder = append(h.functionOrder[:0], h.functionList...)
+	h.notifyOrder = append(h.notifyOrder[:0], h.notifyList...)
+	h.orderStrategy.SortFunctions(h.functionOrder)
+	h.orderStrategy.SortNotifies(h.notifyOrder)
 }
 
 func (h *hook) Exec(ctx context.Context) error {
@@ -101,13 +90,28 @@ func (h *hook) Exec(ctx context.Context) error {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	// functions
-	for _, function := range h.functionOrder {
-		if function.Concurrent {
-			go function.Func(ctx)
-		} else {
-			function.Func(ctx)
+	var errs []error
+
+	// functions: consecutive runs of Concurrent functions are fanned out
+	// together and awaited as a group before the next ordered item runs.
+	for i := 0; i < len(h.functionOrder); {
+		function := h.functionOrder[i]
+		if !function.Concurrent {
+			if err := runFunction(ctx, function); err != nil {
+				errs = append(errs, err)
+			}
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(h.functionOrder) && h.functionOrder[j].Concurrent {
+			j++
+		}
+		if err := h.execConcurrent(ctx, h.functionOrder[i:j]); err != nil {
+			errs = append(errs, err)
 		}
+		i = j
 	}
 
 	// notifies
@@ -127,7 +131,148 @@ func (h *hook) Exec(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// runFunction calls function.Func, wrapping ctx in a context.WithTimeout
+// when function.Timeout is set.
+func runFunction(ctx context.Context, function *Function) error {
+	if function.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, function.Timeout)
+		defer cancel()
+	}
+	return function.Func(ctx)
+}
+
+// runConcurrentFunction wraps runFunction with a recover, since a Concurrent
+// function runs on its own goroutine: a panic there isn't on hook.Exec's
+// call stack and so can't be caught by a panic-recovery HookMiddleware.
+// Recovering here and joining it as an error is the only way such a panic
+// doesn't crash the whole process.
+func runConcurrentFunction(ctx context.Context, function *Function) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("function %q panicked: %v", function.Name, r)
+		}
+	}()
+	return runFunction(ctx, function)
+}
+
+// execConcurrent runs functions (a consecutive run of Concurrent functions)
+// with at most h.maxParallel in flight at once, and joins their errors.
+// DependsOn is honored within the run: functions are split into dependency
+// layers (functionDependencyLayers) and each layer is fanned out and fully
+// awaited before the next layer starts, so a Concurrent function still runs
+// after the Concurrent functions it depends on.
+func (h *hook) execConcurrent(ctx context.Context, functions []*Function) error {
+	var errs []error
+	for _, layer := range functionDependencyLayers(functions) {
+		if err := h.execConcurrentLayer(ctx, layer); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// execConcurrentLayer runs functions (all independent of one another) with
+// at most h.maxParallel in flight at once, and joins their errors.
+func (h *hook) execConcurrentLayer(ctx context.Context, functions []*Function) error {
+	max := h.maxParallel
+	if max <= 0 || max > len(functions) {
+		max = len(functions)
+	}
+
+	sem := make(chan struct{}, max)
+	errCh := make(chan error, len(functions))
+	var wg sync.WaitGroup
+
+	for _, function := range functions {
+		function := function
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errCh <- runConcurrentFunction(ctx, function)
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	var errs []error
+	for err := range errCh {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// functionDependencyLayers splits functions (a consecutive run of Concurrent
+// functions) into layers via Kahn's algorithm restricted to this run: layer 0
+// holds every function with no DependsOn still inside the run, layer 1 holds
+// those that only depend on layer 0, and so on. A DependsOn naming a function
+// outside the run is ignored here; under TopologicalOrder the surrounding
+// ordering has already placed that function before this run, while other
+// strategies do not order by DependsOn. A cycle can't occur among Concurrent functions
+// that reach this point, since hook.Function rejects cycles at registration
+// time; if one slipped through anyway, the remaining functions run as one
+// final layer rather than deadlock.
+func functionDependencyLayers(functions []*Function) [][]*Function {
+	index := make(map[string]int, len(functions))
+	for i, f := range functions {
+		index[f.Name] = i
+	}
+
+	indegree := make([]int, len(functions))
+	dependents := make([][]int, len(functions))
+	for i, f := range functions {
+		for _, dep := range f.DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			dependents[j] = append(dependents[j], i)
+			indegree[i]++
+		}
+	}
+
+	done := make([]bool, len(functions))
+	remaining := len(functions)
+
+	var layers [][]*Function
+	for remaining > 0 {
+		var layer []*Function
+		var layerIdx []int
+		for i, f := range functions {
+			if !done[i] && indegree[i] == 0 {
+				layer = append(layer, f)
+				layerIdx = append(layerIdx, i)
+			}
+		}
+		if len(layer) == 0 {
+			// A cycle slipped past registration; run whatever is left
+			// together rather than deadlock forever.
+			for i, f := range functions {
+				if !done[i] {
+					layer = append(layer, f)
+					layerIdx = append(layerIdx, i)
+				}
+			}
+		}
+
+		for _, i := range layerIdx {
+			done[i] = true
+			remaining--
+			for _, next := range dependents[i] {
+				indegree[next]--
+			}
+		}
+		layers = append(layers, layer)
+	}
+	return layers
 }
 
 func (h *hook) GetFunction(name string) *Function {
@@ -160,12 +305,40 @@ func (h *hook) Function(function *Function) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if _, ok := h.functions[function.Name]; ok && !function.Overwrite {
+	_, exists := h.functions[function.Name]
+	if exists && !function.Overwrite {
 		return fmt.Errorf("%w: \"%s\"", ErrFunctionExists, function.Name)
 	}
 
-	h.doReorder = true
+	names := make([]string, 0, len(h.functionList)+1)
+	deps := make(map[string][]string, len(h.functionList)+1)
+	for _, f := range h.functionList {
+		if f.Name == function.Name {
+			continue // superseded by function below
+		}
+		names = append(names, f.Name)
+		deps[f.Name] = f.DependsOn
+	}
+	names = append(names, function.Name)
+	deps[function.Name] = function.DependsOn
+
+	if err := detectDependencyCycle(names, deps); err != nil {
+		return err
+	}
+
 	h.functions[function.Name] = function
+	if exists {
+		for i, f := range h.functionList {
+			if f.Name == function.Name {
+				h.functionList[i] = function
+				break
+			}
+		}
+	} else {
+		h.functionList = append(h.functionList, function)
+	}
+
+	h.doReorder = true
 	return nil
 }
 
@@ -181,22 +354,55 @@ func (h *hook) Notify(notify *Notify) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if _, ok := h.notifies[notify.Name]; ok && !notify.Overwrite {
+	_, exists := h.notifies[notify.Name]
+	if exists && !notify.Overwrite {
 		return fmt.Errorf("%w: \"%s\"", ErrNotifyExists, notify.Name)
 	}
 
-	h.doReorder = true
+	names := make([]string, 0, len(h.notifyList)+1)
+	deps := make(map[string][]string, len(h.notifyList)+1)
+	for _, n := range h.notifyList {
+		if n.Name == notify.Name {
+			continue // superseded by notify below
+		}
+		names = append(names, n.Name)
+		deps[n.Name] = n.DependsOn
+	}
+	names = append(names, notify.Name)
+	deps[notify.Name] = notify.DependsOn
+
+	if err := detectDependencyCycle(names, deps); err != nil {
+		return err
+	}
+
 	h.notifies[notify.Name] = notify
+	if exists {
+		for i, n := range h.notifyList {
+			if n.Name == notify.Name {
+				h.notifyList[i] = notify
+				break
+			}
+		}
+	} else {
+		h.notifyList = append(h.notifyList, notify)
+	}
+
+	h.doReorder = true
 	return nil
 }
 
 var _ Hook = (*hook)(nil)
 
-func NewHook(name, desc string) Hook {
-	return &hook{
-		name:      name,
-		desc:      desc,
-		functions: make(map[string]*Function),
-		notifies:  make(map[string]*Notify),
+func NewHook(name, desc string, opts ...HookOption) Hook {
+	h := &hook{
+		name:          name,
+		desc:          desc,
+		functions:     make(map[string]*Function),
+		notifies:      make(map[string]*Notify),
+		orderStrategy: AscendingOrder(),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
@@ -0,0 +1,137 @@
+package gosignal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecJoinsSequentialErrors(t *testing.T) {
+	h := NewHook("t", "t")
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+
+	_ = h.Function(&Function{Name: "a", Order: 0, Func: func(ctx context.Context) error { return errA }})
+	_ = h.Function(&Function{Name: "b", Order: 1, Func: func(ctx context.Context) error { return errB }})
+
+	err := h.Exec(context.Background())
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("expected both errors joined, got %v", err)
+	}
+}
+
+func TestExecConcurrentJoinsErrors(t *testing.T) {
+	h := NewHook("t", "t")
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+
+	_ = h.Function(&Function{Name: "a", Concurrent: true, Func: func(ctx context.Context) error { return errA }})
+	_ = h.Function(&Function{Name: "b", Concurrent: true, Func: func(ctx context.Context) error { return nil }})
+	_ = h.Function(&Function{Name: "c", Concurrent: true, Func: func(ctx context.Context) error { return errB }})
+
+	err := h.Exec(context.Background())
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("expected both errors joined, got %v", err)
+	}
+}
+
+func TestExecNoErrorsReturnsNil(t *testing.T) {
+	h := NewHook("t", "t")
+	_ = h.Function(&Function{Name: "a", Func: func(ctx context.Context) error { return nil }})
+	_ = h.Function(&Function{Name: "b", Concurrent: true, Func: func(ctx context.Context) error { return nil }})
+
+	if err := h.Exec(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestExecConcurrentRespectsMaxParallel(t *testing.T) {
+	h := NewHook("t", "t", WithMaxParallel(1))
+
+	var inFlight, maxInFlight atomic.Int64
+
+	track := func() func() {
+		n := inFlight.Add(1)
+		for {
+			prev := maxInFlight.Load()
+			if n <= prev || maxInFlight.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+		return func() { inFlight.Add(-1) }
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		_ = h.Function(&Function{Name: name, Concurrent: true, Func: func(ctx context.Context) error {
+			done := track()
+			defer done()
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}})
+	}
+
+	if err := h.Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := maxInFlight.Load(); got > 1 {
+		t.Fatalf("expected at most 1 function in flight, saw %d", got)
+	}
+}
+
+func TestExecConcurrentRespectsDependsOn(t *testing.T) {
+	h := NewHook("t", "t", WithOrderStrategy(TopologicalOrder()))
+
+	var mu sync.Mutex
+	var order []string
+
+	_ = h.Function(&Function{Name: "a", Concurrent: true, Func: func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		order = append(order, "a")
+		mu.Unlock()
+		return nil
+	}})
+	_ = h.Function(&Function{Name: "b", Concurrent: true, DependsOn: []string{"a"}, Func: func(ctx context.Context) error {
+		mu.Lock()
+		order = append(order, "b")
+		mu.Unlock()
+		return nil
+	}})
+
+	if err := h.Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "a" || order[1] != "b" {
+		t.Fatalf("expected [a b], got %v", order)
+	}
+}
+
+func TestRunFunctionTimeout(t *testing.T) {
+	f := &Function{
+		Name:    "slow",
+		Timeout: time.Millisecond,
+		Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	if err := runFunction(context.Background(), f); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRunConcurrentFunctionRecoversPanic(t *testing.T) {
+	f := &Function{Name: "boom", Func: func(ctx context.Context) error {
+		panic("kaboom")
+	}}
+
+	err := runConcurrentFunction(context.Background(), f)
+	if err == nil {
+		t.Fatal("expected the panic to be converted into an error")
+	}
+}
@@ -0,0 +1,31 @@
+package gosignal
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrExitVetoed is returned by a HookMiddleware to stop an in-progress exit.
+// When handler.exit sees this error come back from the composed chain, it
+// aborts the exit instead of calling os.Exit.
+var ErrExitVetoed = errors.New("exit vetoed by middleware")
+
+// FuncHandle is a single step in a handler's middleware chain. sig is the
+// OSSignal that triggered the current signal or exit hook; it is the same
+// value stored in ctx and is passed alongside it for convenience.
+type FuncHandle func(ctx context.Context, sig *OSSignal) error
+
+// HookMiddleware wraps a FuncHandle with cross-cutting behavior (logging,
+// tracing, metrics, panic recovery, timeouts, veto logic, ...). Middlewares
+// are composed around every hook.Exec invocation the handler makes, for
+// both signal-specific hooks and the exit hook.
+type HookMiddleware func(next FuncHandle) FuncHandle
+
+// chain composes mws around final, with mws[0] being the outermost layer.
+func chain(mws []HookMiddleware, final FuncHandle) FuncHandle {
+	h := final
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
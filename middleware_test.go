@@ -0,0 +1,120 @@
+package gosignal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestChainComposesOutermostFirst(t *testing.T) {
+	var calls []string
+
+	record := func(name string) HookMiddleware {
+		return func(next FuncHandle) FuncHandle {
+			return func(ctx context.Context, sig *OSSignal) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, sig)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	final := func(ctx context.Context, sig *OSSignal) error {
+		calls = append(calls, "final")
+		return nil
+	}
+
+	h := chain([]HookMiddleware{record("outer"), record("inner")}, final)
+	if err := h(context.Background(), &OSSignal{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"outer:before", "inner:before", "final", "inner:after", "outer:after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, calls)
+		}
+	}
+}
+
+func TestChainNoMiddlewareRunsFinal(t *testing.T) {
+	ran := false
+	final := func(ctx context.Context, sig *OSSignal) error {
+		ran = true
+		return nil
+	}
+
+	if err := chain(nil, final)(context.Background(), &OSSignal{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected final to run")
+	}
+}
+
+func TestChainShortCircuitsOnVeto(t *testing.T) {
+	innerRan := false
+	veto := func(next FuncHandle) FuncHandle {
+		return func(ctx context.Context, sig *OSSignal) error {
+			return ErrExitVetoed
+		}
+	}
+	final := func(ctx context.Context, sig *OSSignal) error {
+		innerRan = true
+		return nil
+	}
+
+	err := chain([]HookMiddleware{veto}, final)(context.Background(), &OSSignal{})
+	if !errors.Is(err, ErrExitVetoed) {
+		t.Fatalf("expected ErrExitVetoed, got %v", err)
+	}
+	if innerRan {
+		t.Fatal("expected final to be skipped once vetoed")
+	}
+}
+
+// fakeSignal is a minimal os.Signal used to exercise signal handling in
+// tests without depending on a platform-specific syscall signal.
+type fakeSignal string
+
+func (f fakeSignal) String() string { return string(f) }
+func (f fakeSignal) Signal()        {}
+
+func TestHandlerUseRunsMiddlewareAroundSignalHook(t *testing.T) {
+	h := newHandler().(*handler)
+
+	var calls []string
+	h.Use(func(next FuncHandle) FuncHandle {
+		return func(ctx context.Context, sig *OSSignal) error {
+			calls = append(calls, "mw:before")
+			err := next(ctx, sig)
+			calls = append(calls, "mw:after")
+			return err
+		}
+	})
+
+	var sig os.Signal = fakeSignal("fake")
+	hook := NewHook("fake", "test")
+	_ = hook.Function(&Function{Name: "f", Func: func(ctx context.Context) error {
+		calls = append(calls, "hook")
+		return nil
+	}})
+	_ = h.Set(sig, hook)
+
+	h.handleSignal(sig)
+
+	want := []string{"mw:before", "hook", "mw:after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, calls)
+		}
+	}
+}
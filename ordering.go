@@ -0,0 +1,187 @@
+package gosignal
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrDependencyCycle is returned by hook.Function/hook.Notify when a
+// DependsOn graph including the new registration would contain a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle detected")
+
+// OrderStrategy controls how a hook's functions and notifies are sorted
+// before each Exec. Strategies sort in place.
+type OrderStrategy interface {
+	SortFunctions([]*Function)
+	SortNotifies([]*Notify)
+}
+
+// AscendingOrder sorts by ascending Order. It is the default strategy and
+// matches the hook's historical behavior.
+func AscendingOrder() OrderStrategy { return ascendingOrder{} }
+
+type ascendingOrder struct{}
+
+func (ascendingOrder) SortFunctions(fns []*Function) {
+	sort.SliceStable(fns, func(i, j int) bool { return fns[i].Order < fns[j].Order })
+}
+
+func (ascendingOrder) SortNotifies(ns []*Notify) {
+	sort.SliceStable(ns, func(i, j int) bool { return ns[i].Order < ns[j].Order })
+}
+
+// DescendingOrder sorts by descending Order.
+func DescendingOrder() OrderStrategy { return descendingOrder{} }
+
+type descendingOrder struct{}
+
+func (descendingOrder) SortFunctions(fns []*Function) {
+	sort.SliceStable(fns, func(i, j int) bool { return fns[i].Order > fns[j].Order })
+}
+
+func (descendingOrder) SortNotifies(ns []*Notify) {
+	sort.SliceStable(ns, func(i, j int) bool { return ns[i].Order > ns[j].Order })
+}
+
+// InsertionOrder leaves functions and notifies in the order they were
+// registered.
+func InsertionOrder() OrderStrategy { return insertionOrder{} }
+
+type insertionOrder struct{}
+
+func (insertionOrder) SortFunctions([]*Function) {}
+func (insertionOrder) SortNotifies([]*Notify)     {}
+
+// TopologicalOrder sorts by each item's DependsOn graph, so a function or
+// notify always runs after everything it depends on. A dependency naming an
+// item that isn't registered on this hook is ignored. If the graph contains
+// a cycle (one slipped past registration) it leaves the slice untouched.
+func TopologicalOrder() OrderStrategy { return topologicalOrder{} }
+
+type topologicalOrder struct{}
+
+func (topologicalOrder) SortFunctions(fns []*Function) {
+	names := make([]string, len(fns))
+	deps := make(map[string][]string, len(fns))
+	index := make(map[string]int, len(fns))
+	for i, f := range fns {
+		names[i] = f.Name
+		deps[f.Name] = f.DependsOn
+		index[f.Name] = i
+	}
+
+	order, ok := topoSort(names, deps)
+	if !ok {
+		return
+	}
+	sorted := make([]*Function, len(order))
+	for i, name := range order {
+		sorted[i] = fns[index[name]]
+	}
+	copy(fns, sorted)
+}
+
+func (topologicalOrder) SortNotifies(ns []*Notify) {
+	names := make([]string, len(ns))
+	deps := make(map[string][]string, len(ns))
+	index := make(map[string]int, len(ns))
+	for i, n := range ns {
+		names[i] = n.Name
+		deps[n.Name] = n.DependsOn
+		index[n.Name] = i
+	}
+
+	order, ok := topoSort(names, deps)
+	if !ok {
+		return
+	}
+	sorted := make([]*Notify, len(order))
+	for i, name := range order {
+		sorted[i] = ns[index[name]]
+	}
+	copy(ns, sorted)
+}
+
+// topoSort runs Kahn's algorithm over names/deps with a FIFO ready queue:
+// items with no dependencies are queued in their order in names, and every
+// other item is queued when its last dependency is emitted, so the result
+// is deterministic and, without dependencies, is the order of names. ok is
+// false if the graph contains a cycle, in which case order is not a valid
+// ordering.
+func topoSort(names []string, deps map[string][]string) (order []string, ok bool) {
+	indegree := make(map[string]int, len(names))
+	adj := make(map[string][]string, len(names))
+	for _, name := range names {
+		indegree[name] = 0
+	}
+	for _, name := range names {
+		for _, dep := range deps[name] {
+			if _, known := indegree[dep]; !known {
+				continue // dependency isn't registered on this hook; ignore
+			}
+			adj[dep] = append(adj[dep], name)
+			indegree[name]++
+		}
+	}
+
+	ready := make([]string, 0, len(names))
+	for _, name := range names {
+		if indegree[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order = make([]string, 0, len(names))
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+
+		for _, next := range adj[name] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	return order, len(order) == len(names)
+}
+
+// detectDependencyCycle reports whether the DependsOn graph described by
+// names/deps contains a cycle.
+func detectDependencyCycle(names []string, deps map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(names))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %q", ErrDependencyCycle, name)
+		}
+
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package gosignal
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTopoSortOrdersByDependency(t *testing.T) {
+	deps := map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"b"},
+	}
+	order, ok := topoSort([]string{"c", "b", "a"}, deps)
+	if !ok {
+		t.Fatal("expected a valid order")
+	}
+
+	pos := make(map[string]int, len(order))
+	for i, name := range order {
+		pos[name] = i
+	}
+	if pos["a"] >= pos["b"] || pos["b"] >= pos["c"] {
+		t.Fatalf("dependencies out of order: %v", order)
+	}
+}
+
+func TestTopoSortStableWithoutDependencies(t *testing.T) {
+	names := []string{"c", "b", "a"}
+	order, ok := topoSort(names, map[string][]string{})
+	if !ok {
+		t.Fatal("expected a valid order")
+	}
+	for i, name := range names {
+		if order[i] != name {
+			t.Fatalf("expected insertion order to be preserved, got %v", order)
+		}
+	}
+}
+
+func TestTopoSortDetectsCycle(t *testing.T) {
+	deps := map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+	}
+	if _, ok := topoSort([]string{"a", "b"}, deps); ok {
+		t.Fatal("expected topoSort to reject a cycle")
+	}
+}
+
+func TestTopoSortIgnoresUnknownDependency(t *testing.T) {
+	deps := map[string][]string{
+		"a": {"does-not-exist"},
+	}
+	order, ok := topoSort([]string{"a"}, deps)
+	if !ok || len(order) != 1 || order[0] != "a" {
+		t.Fatalf("expected unknown dependency to be ignored, got %v ok=%v", order, ok)
+	}
+}
+
+func TestDetectDependencyCycleNoCycle(t *testing.T) {
+	deps := map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"a", "b"},
+	}
+	if err := detectDependencyCycle([]string{"a", "b", "c"}, deps); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDetectDependencyCycleDirect(t *testing.T) {
+	deps := map[string][]string{
+		"a": {"a"},
+	}
+	if err := detectDependencyCycle([]string{"a"}, deps); err == nil {
+		t.Fatal("expected a self-dependency to be rejected")
+	}
+}
+
+func TestDetectDependencyCycleTransitive(t *testing.T) {
+	deps := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	}
+	if err := detectDependencyCycle([]string{"a", "b", "c"}, deps); err == nil {
+		t.Fatal("expected a transitive cycle to be rejected")
+	}
+}
+
+func TestFunctionRejectsDependencyCycle(t *testing.T) {
+	h := NewHook("t", "t", WithOrderStrategy(TopologicalOrder()))
+
+	noop := func(ctx context.Context) error { return nil }
+	if err := h.Function(&Function{Name: "a", DependsOn: []string{"b"}, Func: noop}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.Function(&Function{Name: "b", DependsOn: []string{"a"}, Func: noop}); err == nil {
+		t.Fatal("expected cycle to be rejected at registration time")
+	}
+}
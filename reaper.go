@@ -0,0 +1,116 @@
+//go:build !windows
+
+package gosignal
+
+import (
+	"context"
+	"sync"
+	"syscall"
+)
+
+// ChildExit describes a single reaped child process.
+type ChildExit struct {
+	PID    int
+	Status syscall.WaitStatus
+	Rusage syscall.Rusage
+}
+
+// Reaper captures SIGCHLD on behalf of a Handler and demultiplexes child-exit
+// events to per-PID callbacks, so callers can supervise child processes
+// without racing their own signal.Notify(SIGCHLD).
+type Reaper interface {
+	// Track registers cb to be called, on the handler's goroutine, when pid
+	// is reaped. Overwrites any callback already tracked for pid.
+	Track(pid int, cb func(pid int, ws syscall.WaitStatus))
+	// Untrack removes any callback registered for pid.
+	Untrack(pid int)
+	// Events returns a channel fed with every reaped child, regardless of
+	// whether it has a tracked callback. The channel is buffered but not
+	// unbounded: a ChildExit is dropped if the consumer isn't keeping up
+	// and the buffer is full, so don't rely on it for children that must
+	// never be missed — use Track for those.
+	Events() <-chan ChildExit
+}
+
+// impl Reaper
+type reaper struct {
+	mu        sync.RWMutex
+	callbacks map[int]func(pid int, ws syscall.WaitStatus)
+	events    chan ChildExit
+}
+
+// NewReaper installs a SIGCHLD hook on h and returns a Reaper that
+// demultiplexes reaped children to per-PID callbacks. There must be no
+// existing hook for SIGCHLD on h.
+func NewReaper(h Handler) (Reaper, error) {
+	r := &reaper{
+		callbacks: make(map[int]func(pid int, ws syscall.WaitStatus)),
+		events:    make(chan ChildExit, 16),
+	}
+
+	hook := NewHook("reaper.sigchld", "Reap terminated child processes")
+	if err := hook.Function(&Function{
+		Name: "reap",
+		Desc: "drain pending child exits via Wait4",
+		Func: func(ctx context.Context) error {
+			r.reap()
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := h.Set(syscall.SIGCHLD, hook); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// reap drains every child that has exited since the last SIGCHLD, calling
+// the pid's registered callback (if any) and publishing a ChildExit event.
+func (r *reaper) reap() {
+	for {
+		var ws syscall.WaitStatus
+		var ru syscall.Rusage
+
+		pid, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, &ru)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || pid <= 0 {
+			// ECHILD (no children left) or nothing left to reap this pass.
+			return
+		}
+
+		r.mu.RLock()
+		cb := r.callbacks[pid]
+		r.mu.RUnlock()
+		if cb != nil {
+			cb(pid, ws)
+		}
+
+		select {
+		case r.events <- ChildExit{PID: pid, Status: ws, Rusage: ru}:
+		default:
+		}
+	}
+}
+
+func (r *reaper) Track(pid int, cb func(pid int, ws syscall.WaitStatus)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callbacks[pid] = cb
+}
+
+func (r *reaper) Untrack(pid int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.callbacks, pid)
+}
+
+func (r *reaper) Events() <-chan ChildExit {
+	return r.events
+}
+
+var _ Reaper = (*reaper)(nil)
@@ -0,0 +1,105 @@
+//go:build !windows
+
+package gosignal
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// spawnExitingChild starts a child process that exits immediately with code
+// and returns its PID. The process is intentionally never Wait()'d by the
+// exec package, so it's left for the reaper under test to reap via Wait4.
+func spawnExitingChild(t *testing.T, code int) (*exec.Cmd, int) {
+	t.Helper()
+	cmd := exec.Command("/bin/sh", "-c", "exit "+string(rune('0'+code)))
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start child: %v", err)
+	}
+	return cmd, cmd.Process.Pid
+}
+
+// reapUntil repeatedly invokes the reaper's hook, giving the child time to
+// become a zombie, until cond reports done or the deadline passes.
+func reapUntil(t *testing.T, hook Hook, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := hook.Exec(context.Background()); err != nil {
+			t.Fatalf("hook.Exec: %v", err)
+		}
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for child to be reaped")
+}
+
+func TestReaperTrackDeliversChildExit(t *testing.T) {
+	h := newHandler()
+	r, err := NewReaper(h)
+	if err != nil {
+		t.Fatalf("NewReaper: %v", err)
+	}
+	hook := h.Get(syscall.SIGCHLD)
+	if hook == nil {
+		t.Fatal("expected NewReaper to register a SIGCHLD hook")
+	}
+
+	cmd, pid := spawnExitingChild(t, 7)
+	defer cmd.Process.Release()
+
+	got := make(chan syscall.WaitStatus, 1)
+	r.Track(pid, func(p int, ws syscall.WaitStatus) {
+		if p == pid {
+			got <- ws
+		}
+	})
+
+	reapUntil(t, hook, func() bool {
+		select {
+		case ws := <-got:
+			if !ws.Exited() || ws.ExitStatus() != 7 {
+				t.Fatalf("unexpected wait status: %+v", ws)
+			}
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func TestReaperUntrackStopsCallback(t *testing.T) {
+	h := newHandler()
+	r, err := NewReaper(h)
+	if err != nil {
+		t.Fatalf("NewReaper: %v", err)
+	}
+	hook := h.Get(syscall.SIGCHLD)
+
+	cmd, pid := spawnExitingChild(t, 3)
+	defer cmd.Process.Release()
+
+	called := false
+	r.Track(pid, func(p int, ws syscall.WaitStatus) { called = true })
+	r.Untrack(pid)
+
+	reapUntil(t, hook, func() bool {
+		select {
+		case exit := <-r.Events():
+			if exit.PID != pid {
+				return false
+			}
+			if called {
+				t.Fatal("expected untracked callback not to run")
+			}
+			return true
+		default:
+			return false
+		}
+	})
+}
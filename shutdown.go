@@ -0,0 +1,10 @@
+package gosignal
+
+import "context"
+
+// Drainable is an in-flight tracker that handler.exit waits on, up to the
+// handler's ShutdownGracePeriod, before calling os.Exit. Wait should return
+// once the tracked work has finished, or promptly once ctx is done.
+type Drainable interface {
+	Wait(ctx context.Context) error
+}
@@ -0,0 +1,51 @@
+package gosignal
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestSignalFromContextRoundTrips(t *testing.T) {
+	if _, ok := SignalFromContext(context.Background()); ok {
+		t.Fatal("expected no OSSignal on a bare context")
+	}
+
+	want := &OSSignal{Signal: fakeSignal("fake")}
+	ctx := context.WithValue(context.Background(), signalContextKey{}, want)
+
+	got, ok := SignalFromContext(ctx)
+	if !ok || got != want {
+		t.Fatalf("expected %v, ok=true, got %v, ok=%v", want, got, ok)
+	}
+}
+
+func TestHandlerAssignsIncreasingSequence(t *testing.T) {
+	h := newHandler().(*handler)
+
+	var seqs []uint64
+	hook := NewHook("fake", "test")
+	_ = hook.Function(&Function{Name: "f", Func: func(ctx context.Context) error {
+		s, ok := SignalFromContext(ctx)
+		if !ok {
+			t.Fatal("expected the hook's context to carry an OSSignal")
+		}
+		seqs = append(seqs, s.Sequence)
+		return nil
+	}})
+	var sig os.Signal = fakeSignal("fake")
+	_ = h.Set(sig, hook)
+
+	h.handleSignal(sig)
+	h.handleSignal(sig)
+	h.handleSignal(sig)
+
+	if len(seqs) != 3 {
+		t.Fatalf("expected 3 recorded sequences, got %d", len(seqs))
+	}
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			t.Fatalf("expected strictly increasing sequence numbers, got %v", seqs)
+		}
+	}
+}